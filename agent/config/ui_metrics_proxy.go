@@ -0,0 +1,94 @@
+package config
+
+import "fmt"
+
+// UIMetricsProxy configures the optional reverse proxy the UI uses to reach
+// one or more metrics (and log) backends, so operators don't need to run an
+// external reverse proxy in front of Consul just to fan requests out to
+// Prometheus, Loki, Elasticsearch, etc.
+type UIMetricsProxy struct {
+	// Backends is the set of named proxy targets the UI can reach via
+	// /v1/internal/ui/metrics-proxy/<name>/.... The metrics proxy is
+	// disabled when this is empty.
+	Backends []UIMetricsProxyBackend `mapstructure:"backend"`
+}
+
+// UIMetricsProxyBackend describes a single upstream the metrics proxy is
+// allowed to forward requests to.
+type UIMetricsProxyBackend struct {
+	// Name selects this backend in the proxy URL and is returned from GET
+	// /v1/internal/ui/metrics-proxy-backends.
+	Name string `mapstructure:"name"`
+
+	// Kind is a free-form hint (e.g. "prometheus", "loki", "elasticsearch")
+	// that lets the UI decide how to query this backend. Consul itself
+	// doesn't interpret it.
+	Kind string `mapstructure:"kind"`
+
+	// BaseURL is the address requests are proxied to.
+	BaseURL string `mapstructure:"base_url"`
+
+	// PathPrefix is an optional path inserted between BaseURL and the
+	// request's sub-path, for backends that are mounted under a prefix.
+	PathPrefix string `mapstructure:"path_prefix"`
+
+	// PathAllowlist restricts which sub-paths may be proxied to this
+	// backend. If empty, all paths under BaseURL are allowed.
+	PathAllowlist []string `mapstructure:"path_allowlist"`
+
+	// AddHeaders are static headers injected on every request forwarded to
+	// this backend, e.g. "Authorization" for a backend that needs a
+	// bearer token, or "X-Scope-OrgID" for a multi-tenant Cortex/Loki
+	// install. A client can never override these by sending a header of
+	// the same name.
+	AddHeaders []UIMetricsProxyAddHeader `mapstructure:"add_headers"`
+
+	// ForwardHeaders lists the headers from the incoming UI request that
+	// should be forwarded to the backend. Any header not in this list is
+	// dropped rather than forwarded.
+	ForwardHeaders []string `mapstructure:"forward_headers"`
+
+	// RequireACLToken, when true, requires that the ACL token presented by
+	// the UI satisfy ACLRule before the request is proxied to this
+	// backend.
+	RequireACLToken bool `mapstructure:"require_acl_token"`
+
+	// ACLRule overrides the ACL check performed when RequireACLToken is
+	// set. Must be one of validUIMetricsProxyACLRules. Defaults to
+	// "operator:read".
+	ACLRule string `mapstructure:"acl_rule"`
+}
+
+// validUIMetricsProxyACLRules enumerates the values UIMetricsProxyBackend.ACLRule
+// may take. Keep this in sync with the acl.Authorizer methods
+// uiMetricsProxyACLAllowed dispatches on in agent/ui_endpoint.go - an
+// ACLRule that isn't in this set is rejected by Validate rather than
+// silently falling back to the "operator:read" default.
+var validUIMetricsProxyACLRules = map[string]bool{
+	"":               true,
+	"operator:read":  true,
+	"operator:write": true,
+}
+
+// Validate reports an error if b.ACLRule is not in validUIMetricsProxyACLRules,
+// e.g. a typo'd or unsupported rule (such as "Operator:Write" or
+// "mesh:read"). It checks no other field of b. Callers that gate on
+// RequireACLToken must call this before trusting ACLRule, since an invalid
+// value must be rejected rather than silently treated as the default rule;
+// UIMetricsProxy and UIMetricsProxyBackends in agent/ui_endpoint.go do this
+// on every request that reaches a RequireACLToken backend. This is not
+// currently wired into config loading/building, so a misconfigured acl_rule
+// isn't caught until the first request hits that backend.
+func (b UIMetricsProxyBackend) Validate() error {
+	if !validUIMetricsProxyACLRules[b.ACLRule] {
+		return fmt.Errorf("ui_config.metrics_proxy backend %q has invalid acl_rule %q", b.Name, b.ACLRule)
+	}
+	return nil
+}
+
+// UIMetricsProxyAddHeader is a single static header to inject into requests
+// forwarded to a metrics-proxy backend.
+type UIMetricsProxyAddHeader struct {
+	Name  string `mapstructure:"name"`
+	Value string `mapstructure:"value"`
+}
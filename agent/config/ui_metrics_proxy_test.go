@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUIMetricsProxyBackend_Validate(t *testing.T) {
+	cases := map[string]struct {
+		aclRule string
+		wantErr bool
+	}{
+		"empty defaults to operator:read": {aclRule: "", wantErr: false},
+		"operator:read":                   {aclRule: "operator:read", wantErr: false},
+		"operator:write":                  {aclRule: "operator:write", wantErr: false},
+		"unknown rule":                    {aclRule: "mesh:read", wantErr: true},
+		"wrong case is not normalized":    {aclRule: "Operator:Read", wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := UIMetricsProxyBackend{Name: "prom", ACLRule: tc.aclRule}
+			err := b.Validate()
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package agent
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"path"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/config"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/api"
@@ -86,7 +90,30 @@ RPC:
 	if out.Dump == nil {
 		out.Dump = make(structs.NodeDump, 0)
 	}
-	return out.Dump, nil
+
+	if wantsNDJSON(req) {
+		emit := startNDJSONStream(resp)
+		for _, info := range out.Dump {
+			if err := emit(info); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	page, err := parseUIPage(req)
+	if err != nil {
+		return nil, err
+	}
+	dump, next := paginateNodeDump(out.Dump, page)
+	if next != "" {
+		resp.Header().Set("X-Consul-Next-Cursor", next)
+	}
+
+	if fields := parseUIFields(req); len(fields) > 0 {
+		return projectUIFields(dump, fields), nil
+	}
+	return dump, nil
 }
 
 // UINodeInfo is used to get info on a single node in a given datacenter. We return a
@@ -169,7 +196,30 @@ RPC:
 
 	// Generate the summary
 	// TODO (gateways) (freddy) Have Internal.ServiceDump return ServiceDump instead. Need to add bexpr filtering for type.
-	return summarizeServices(out.Nodes.ToServiceDump(), s.agent.config, args.Datacenter), nil
+	dump := out.Nodes.ToServiceDump()
+
+	if wantsNDJSON(req) {
+		emit := startNDJSONStream(resp)
+		return nil, summarizeServicesIter(dump, s.agent.config, args.Datacenter, func(sum *ServiceSummary) error {
+			return emit(sum)
+		})
+	}
+
+	summaries := summarizeServices(dump, s.agent.config, args.Datacenter)
+
+	page, err := parseUIPage(req)
+	if err != nil {
+		return nil, err
+	}
+	summaries, next := paginateServiceSummaries(summaries, page)
+	if next != "" {
+		resp.Header().Set("X-Consul-Next-Cursor", next)
+	}
+
+	if fields := parseUIFields(req); len(fields) > 0 {
+		return projectUIFields(summaries, fields), nil
+	}
+	return summaries, nil
 }
 
 // UIGatewayServices is used to query all the nodes for services associated with a gateway along with their gateway config
@@ -204,9 +254,86 @@ RPC:
 		return nil, err
 	}
 
+	if wantsNDJSON(req) {
+		emit := startNDJSONStream(resp)
+		return nil, summarizeServicesIter(out.Dump, s.agent.config, args.Datacenter, func(sum *ServiceSummary) error {
+			return emit(sum)
+		})
+	}
+
 	return summarizeServices(out.Dump, s.agent.config, args.Datacenter), nil
 }
 
+// newServiceSummary creates the zero-value summary for a newly-seen service.
+func newServiceSummary(id structs.ServiceID) *ServiceSummary {
+	return &ServiceSummary{
+		Name:           id.ID,
+		EnterpriseMeta: id.EnterpriseMeta,
+		// the other code will increment this unconditionally so we
+		// shouldn't initialize it to 1
+		InstanceCount: 0,
+	}
+}
+
+// addServiceInstanceToSummary folds a single CheckServiceNode into sum. It is
+// the per-instance accumulation step shared by summarizeServices (which
+// builds every summary before returning) and summarizeServicesIter (which
+// emits each summary as soon as its instances are exhausted).
+func addServiceInstanceToSummary(sum *ServiceSummary, csn structs.CheckServiceNode) {
+	svc := csn.Service
+	sum.Nodes = append(sum.Nodes, csn.Node.Node)
+	sum.Kind = svc.Kind
+	sum.InstanceCount += 1
+	if svc.Kind == structs.ServiceKindConnectProxy {
+		if _, ok := sum.proxyForSet[svc.Proxy.DestinationServiceName]; !ok {
+			if sum.proxyForSet == nil {
+				sum.proxyForSet = make(map[string]struct{})
+			}
+			sum.proxyForSet[svc.Proxy.DestinationServiceName] = struct{}{}
+			sum.ProxyFor = append(sum.ProxyFor, svc.Proxy.DestinationServiceName)
+		}
+	}
+	for _, tag := range svc.Tags {
+		found := false
+		for _, existing := range sum.Tags {
+			if existing == tag {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			sum.Tags = append(sum.Tags, tag)
+		}
+	}
+
+	// If there is an external source, add it to the list of external
+	// sources. We only want to add unique sources so there is extra
+	// accounting here with an unexported field to maintain the set
+	// of sources.
+	if len(svc.Meta) > 0 && svc.Meta[metaExternalSource] != "" {
+		source := svc.Meta[metaExternalSource]
+		if sum.externalSourceSet == nil {
+			sum.externalSourceSet = make(map[string]struct{})
+		}
+		if _, ok := sum.externalSourceSet[source]; !ok {
+			sum.externalSourceSet[source] = struct{}{}
+			sum.ExternalSources = append(sum.ExternalSources, source)
+		}
+	}
+
+	for _, check := range csn.Checks {
+		switch check.Status {
+		case api.HealthPassing:
+			sum.ChecksPassing++
+		case api.HealthWarning:
+			sum.ChecksWarning++
+		case api.HealthCritical:
+			sum.ChecksCritical++
+		}
+	}
+}
+
 func summarizeServices(dump structs.ServiceDump, cfg *config.RuntimeConfig, datacenter string) []*ServiceSummary {
 	// Collect the summary information
 	var services []structs.ServiceID
@@ -214,13 +341,7 @@ func summarizeServices(dump structs.ServiceDump, cfg *config.RuntimeConfig, data
 	getService := func(service structs.ServiceID) *ServiceSummary {
 		serv, ok := summary[service]
 		if !ok {
-			serv = &ServiceSummary{
-				Name:           service.ID,
-				EnterpriseMeta: service.EnterpriseMeta,
-				// the other code will increment this unconditionally so we
-				// shouldn't initialize it to 1
-				InstanceCount: 0,
-			}
+			serv = newServiceSummary(service)
 			summary[service] = serv
 			services = append(services, service)
 		}
@@ -239,60 +360,7 @@ func summarizeServices(dump structs.ServiceDump, cfg *config.RuntimeConfig, data
 			continue
 		}
 		sid := structs.NewServiceID(csn.Service.Service, &csn.Service.EnterpriseMeta)
-		sum := getService(sid)
-
-		svc := csn.Service
-		sum.Nodes = append(sum.Nodes, csn.Node.Node)
-		sum.Kind = svc.Kind
-		sum.InstanceCount += 1
-		if svc.Kind == structs.ServiceKindConnectProxy {
-			if _, ok := sum.proxyForSet[svc.Proxy.DestinationServiceName]; !ok {
-				if sum.proxyForSet == nil {
-					sum.proxyForSet = make(map[string]struct{})
-				}
-				sum.proxyForSet[svc.Proxy.DestinationServiceName] = struct{}{}
-				sum.ProxyFor = append(sum.ProxyFor, svc.Proxy.DestinationServiceName)
-			}
-		}
-		for _, tag := range svc.Tags {
-			found := false
-			for _, existing := range sum.Tags {
-				if existing == tag {
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				sum.Tags = append(sum.Tags, tag)
-			}
-		}
-
-		// If there is an external source, add it to the list of external
-		// sources. We only want to add unique sources so there is extra
-		// accounting here with an unexported field to maintain the set
-		// of sources.
-		if len(svc.Meta) > 0 && svc.Meta[metaExternalSource] != "" {
-			source := svc.Meta[metaExternalSource]
-			if sum.externalSourceSet == nil {
-				sum.externalSourceSet = make(map[string]struct{})
-			}
-			if _, ok := sum.externalSourceSet[source]; !ok {
-				sum.externalSourceSet[source] = struct{}{}
-				sum.ExternalSources = append(sum.ExternalSources, source)
-			}
-		}
-
-		for _, check := range csn.Checks {
-			switch check.Status {
-			case api.HealthPassing:
-				sum.ChecksPassing++
-			case api.HealthWarning:
-				sum.ChecksWarning++
-			case api.HealthCritical:
-				sum.ChecksCritical++
-			}
-		}
+		addServiceInstanceToSummary(getService(sid), csn)
 	}
 
 	// Return the services in sorted order
@@ -310,6 +378,84 @@ func summarizeServices(dump structs.ServiceDump, cfg *config.RuntimeConfig, data
 	return output
 }
 
+// serviceDumpSortKey returns the ServiceID csn should be grouped under,
+// whether it came in via the GatewayService mapping or as a normal service
+// instance.
+func serviceDumpSortKey(csn structs.CheckServiceNode) structs.ServiceID {
+	if csn.Service != nil {
+		return structs.NewServiceID(csn.Service.Service, &csn.Service.EnterpriseMeta)
+	}
+	if csn.GatewayService != nil {
+		return csn.GatewayService.Service.ToServiceID()
+	}
+	return structs.ServiceID{}
+}
+
+// summarizeServicesIter is the streaming equivalent of summarizeServices: it
+// folds dump into ServiceSummary values one service at a time and invokes fn
+// as soon as all of a service's instances have been folded in, so callers
+// never need to hold the full summary map in memory. Internal.ServiceDump and
+// Internal.GatewayServiceDump order their results by node, not by service, so
+// a single service's instances are not contiguous in general - we sort a
+// copy of dump by ServiceID up front so the fold-as-we-go grouping below is
+// actually correct, rather than assuming the RPC already did it.
+func summarizeServicesIter(dump structs.ServiceDump, cfg *config.RuntimeConfig, datacenter string, fn func(*ServiceSummary) error) error {
+	sorted := make(structs.ServiceDump, len(dump))
+	copy(sorted, dump)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		si, sj := serviceDumpSortKey(sorted[i]), serviceDumpSortKey(sorted[j])
+		return si.LessThan(&sj)
+	})
+	dump = sorted
+
+	var cur *ServiceSummary
+	var curID structs.ServiceID
+
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+		sort.Strings(cur.Nodes)
+		sort.Strings(cur.Tags)
+		return fn(cur)
+	}
+
+	switchTo := func(id structs.ServiceID) {
+		cur = newServiceSummary(id)
+		curID = id
+	}
+
+	for _, csn := range dump {
+		if csn.GatewayService != nil {
+			gwsvc := csn.GatewayService
+			gwID := gwsvc.Service.ToServiceID()
+			if cur == nil || gwID != curID {
+				if err := flush(); err != nil {
+					return err
+				}
+				switchTo(gwID)
+			}
+			modifySummaryForGatewayService(cfg, datacenter, cur, gwsvc)
+		}
+
+		// Will happen in cases where we only have the GatewayServices mapping
+		if csn.Service == nil {
+			continue
+		}
+
+		sid := structs.NewServiceID(csn.Service.Service, &csn.Service.EnterpriseMeta)
+		if cur == nil || sid != curID {
+			if err := flush(); err != nil {
+				return err
+			}
+			switchTo(sid)
+		}
+		addServiceInstanceToSummary(cur, csn)
+	}
+
+	return flush()
+}
+
 func modifySummaryForGatewayService(
 	cfg *config.RuntimeConfig,
 	datacenter string,
@@ -346,6 +492,168 @@ func modifySummaryForGatewayService(
 	}
 }
 
+// uiPage holds the cursor-style pagination parameters accepted by the UI
+// list endpoints.
+type uiPage struct {
+	// After is the opaque cursor returned as a previous response's
+	// X-Consul-Next-Cursor header; results are returned starting just past
+	// it.
+	After string
+	// Limit caps the number of results returned. Zero means no limit.
+	Limit int
+}
+
+// parseUIPage parses the ?after= and ?limit= query parameters shared by the
+// UI list endpoints.
+func parseUIPage(req *http.Request) (uiPage, error) {
+	q := req.URL.Query()
+	page := uiPage{After: q.Get("after")}
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			return uiPage{}, BadRequestError{Reason: "Invalid limit parameter"}
+		}
+		page.Limit = limit
+	}
+	return page, nil
+}
+
+// paginateServiceSummaries returns the page of summaries following page.After
+// (if set), up to page.Limit entries, along with the cursor to request the
+// next page. summaries must already be sorted by Name, as summarizeServices
+// returns them. Since the cursor is just the Name of the last-returned
+// service, pagination is deterministic against that sorted slice regardless
+// of any bexpr Filter already applied upstream.
+func paginateServiceSummaries(summaries []*ServiceSummary, page uiPage) ([]*ServiceSummary, string) {
+	start := 0
+	if page.After != "" {
+		start = len(summaries)
+		for i, sum := range summaries {
+			if sum.Name > page.After {
+				start = i
+				break
+			}
+		}
+	}
+	if start >= len(summaries) {
+		return []*ServiceSummary{}, ""
+	}
+
+	end := len(summaries)
+	if page.Limit > 0 && start+page.Limit < end {
+		end = start + page.Limit
+	}
+
+	var next string
+	if end < len(summaries) {
+		next = summaries[end-1].Name
+	}
+	return summaries[start:end], next
+}
+
+// paginateNodeDump is the UINodes equivalent of paginateServiceSummaries,
+// keyed on node name.
+func paginateNodeDump(dump structs.NodeDump, page uiPage) (structs.NodeDump, string) {
+	start := 0
+	if page.After != "" {
+		start = len(dump)
+		for i, info := range dump {
+			if info.Node > page.After {
+				start = i
+				break
+			}
+		}
+	}
+	if start >= len(dump) {
+		return structs.NodeDump{}, ""
+	}
+
+	end := len(dump)
+	if page.Limit > 0 && start+page.Limit < end {
+		end = start + page.Limit
+	}
+
+	var next string
+	if end < len(dump) {
+		next = dump[end-1].Node
+	}
+	return dump[start:end], next
+}
+
+// parseUIFields parses the ?fields= query parameter into the list of struct
+// field names the caller wants projected out of each result.
+func parseUIFields(req *http.Request) []string {
+	raw := req.URL.Query().Get("fields")
+	if raw == "" {
+		return nil
+	}
+	var fields []string
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// projectUIFields reduces each element of items (a slice of structs or
+// struct pointers) down to a map containing only the named fields, so large
+// dumps don't have to serialize data the UI isn't going to render.
+func projectUIFields(items interface{}, fields []string) []map[string]interface{} {
+	v := reflect.ValueOf(items)
+	out := make([]map[string]interface{}, v.Len())
+	for i := range out {
+		out[i] = projectUIFieldsOne(v.Index(i).Interface(), fields)
+	}
+	return out
+}
+
+func projectUIFieldsOne(item interface{}, fields []string) map[string]interface{} {
+	v := reflect.ValueOf(item)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	result := make(map[string]interface{}, len(fields))
+	for _, name := range fields {
+		f := v.FieldByName(name)
+		if !f.IsValid() || !f.CanInterface() {
+			continue
+		}
+		result[name] = f.Interface()
+	}
+	return result
+}
+
+// ndjsonContentType is the opt-in Accept header that switches UINodes,
+// UIServices and UIGatewayServicesNodes from a single JSON array response to
+// one JSON object per line, flushed as each record is computed.
+const ndjsonContentType = "application/x-ndjson"
+
+// wantsNDJSON reports whether the caller opted into streaming NDJSON output.
+func wantsNDJSON(req *http.Request) bool {
+	return req.Header.Get("Accept") == ndjsonContentType
+}
+
+// startNDJSONStream sets up resp for NDJSON output and returns a function
+// that encodes and flushes a single record. Pagination and field projection
+// don't apply in this mode - the point is to stream the full, un-batched
+// dump as it's computed.
+func startNDJSONStream(resp http.ResponseWriter) func(v interface{}) error {
+	resp.Header().Set("Content-Type", ndjsonContentType)
+	enc := json.NewEncoder(resp)
+	flusher, ok := resp.(http.Flusher)
+	return func(v interface{}) error {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		if ok {
+			flusher.Flush()
+		}
+		return nil
+	}
+}
+
 // GET /v1/internal/ui/gateway-intentions/:gateway
 func (s *HTTPHandlers) UIGatewayIntentions(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var args structs.IntentionQueryRequest
@@ -385,9 +693,11 @@ func (s *HTTPHandlers) UIGatewayIntentions(resp http.ResponseWriter, req *http.R
 	return reply.Intentions, nil
 }
 
-// UIMetricsProxy handles the /v1/internal/ui/metrics-proxy/ endpoint which, if
-// configured, provides a simple read-only HTTP proxy to a single metrics
-// backend to expose it to the UI.
+// UIMetricsProxy handles the /v1/internal/ui/metrics-proxy/<backend>/
+// endpoint which, if configured, provides a simple read-only HTTP proxy to
+// one of potentially several named metrics (or log) backends to expose them
+// to the UI. The backend is selected by the first path segment after the
+// metrics-proxy prefix.
 func (s *HTTPHandlers) UIMetricsProxy(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	// Check the UI was enabled at agent startup (note this is not reloadable
 	// currently).
@@ -397,28 +707,102 @@ func (s *HTTPHandlers) UIMetricsProxy(resp http.ResponseWriter, req *http.Reques
 
 	// Load reloadable proxy config
 	cfg, ok := s.metricsProxyCfg.Load().(config.UIMetricsProxy)
-	if !ok || cfg.BaseURL == "" {
+	if !ok || len(cfg.Backends) == 0 {
 		// Proxy not configured
 		return nil, NotFoundError{Reason: "Metrics proxy is not enabled"}
 	}
 
 	log := s.agent.logger.Named(logging.UIMetricsProxy)
 
+	// The path is /v1/internal/ui/metrics-proxy/<backend-name>/<rest>. Pull
+	// the backend name off the front and leave the rest as the sub-path to
+	// append to that backend's BaseURL.
+	trimmed := strings.TrimPrefix(req.URL.Path, "/v1/internal/ui/metrics-proxy/")
+	segments := strings.SplitN(trimmed, "/", 2)
+	name := segments[0]
+	if name == "" {
+		return nil, BadRequestError{Reason: "Missing metrics backend name in path."}
+	}
+	var subPath string
+	if len(segments) > 1 {
+		subPath = "/" + segments[1]
+	}
+
+	backend, ok := findUIMetricsProxyBackend(cfg, name)
+	if !ok {
+		return nil, NotFoundError{Reason: fmt.Sprintf("Unknown metrics backend %q", name)}
+	}
+
+	if backend.RequireACLToken {
+		if err := backend.Validate(); err != nil {
+			log.Error("invalid metrics-proxy backend ACL configuration", "backend", backend.Name, "error", err)
+			return nil, fmt.Errorf("metrics proxy backend %q is misconfigured", backend.Name)
+		}
+
+		var token string
+		s.parseToken(req, &token)
+
+		authz, err := s.agent.resolveToken(token)
+		if err != nil {
+			return nil, err
+		}
+		if authz != nil && !uiMetricsProxyACLAllowed(authz, backend.ACLRule) {
+			return nil, acl.ErrPermissionDenied
+		}
+	}
+
 	// Construct the new URL from the path and the base path. Note we do this here
 	// not in the Director function below because we can handle any errors cleanly
 	// here.
+	u, err := buildMetricsProxyTargetURL(backend, subPath)
+	if err != nil {
+		log.Error("couldn't construct target URL",
+			"backend", backend.Name,
+			"base_url", backend.BaseURL,
+			"path", subPath,
+			"error", err,
+		)
+		return nil, BadRequestError{Reason: "Invalid path."}
+	}
+
+	proxy := httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			r.URL = u
+			uiMetricsProxySetHeaders(r, req, backend)
+		},
+		ErrorLog: log.StandardLogger(&hclog.StandardLoggerOptions{
+			InferLevels: true,
+		}),
+	}
+
+	proxy.ServeHTTP(resp, req)
+	return nil, nil
+}
 
-	// Replace prefix in the path
-	subPath := strings.TrimPrefix(req.URL.Path, "/v1/internal/ui/metrics-proxy")
+// findUIMetricsProxyBackend looks up a configured backend by name.
+func findUIMetricsProxyBackend(cfg config.UIMetricsProxy, name string) (config.UIMetricsProxyBackend, bool) {
+	for _, b := range cfg.Backends {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return config.UIMetricsProxyBackend{}, false
+}
 
-	// Append that to the BaseURL (which might contain a path prefix component)
-	newURL := cfg.BaseURL + subPath
+// buildMetricsProxyTargetURL resolves subPath against backend's BaseURL
+// (and optional PathPrefix), applying the same path-traversal/BaseURL-prefix
+// validation and allowlist check independently for each backend.
+func buildMetricsProxyTargetURL(backend config.UIMetricsProxyBackend, subPath string) (*url.URL, error) {
+	if len(backend.PathAllowlist) > 0 && !uiMetricsProxyPathAllowed(subPath, backend.PathAllowlist) {
+		return nil, fmt.Errorf("path is not in the backend's allowlist")
+	}
+
+	newURL := backend.BaseURL + backend.PathPrefix + subPath
 
 	// Parse it into a new URL
 	u, err := url.Parse(newURL)
 	if err != nil {
-		log.Error("couldn't parse target URL", "base_url", cfg.BaseURL, "path", subPath)
-		return nil, BadRequestError{Reason: "Invalid path."}
+		return nil, err
 	}
 
 	// Clean the new URL path to prevent path traversal attacks and remove any
@@ -435,24 +819,118 @@ func (s *HTTPHandlers) UIMetricsProxy(resp http.ResponseWriter, req *http.Reques
 	// hit this handler. Any /../ that are far enough into the path to hit this
 	// handler, can't backtrack far enough to eat into the BaseURL either. But we
 	// leave this in anyway in case something changes in the future.
-	if !strings.HasPrefix(u.String(), cfg.BaseURL) {
-		log.Error("target URL escaped from base path",
-			"base_url", cfg.BaseURL,
-			"path", subPath,
-			"target_url", u.String(),
-		)
-		return nil, BadRequestError{Reason: "Invalid path."}
+	if !strings.HasPrefix(u.String(), backend.BaseURL) {
+		return nil, fmt.Errorf("target URL escaped from base path")
 	}
 
-	proxy := httputil.ReverseProxy{
-		Director: func(r *http.Request) {
-			r.URL = u
-		},
-		ErrorLog: log.StandardLogger(&hclog.StandardLoggerOptions{
-			InferLevels: true,
-		}),
+	return u, nil
+}
+
+// uiMetricsProxyPathAllowed reports whether subPath is covered by one of the
+// entries in allowlist, either as an exact match or as a path prefix.
+func uiMetricsProxyPathAllowed(subPath string, allowlist []string) bool {
+	for _, allowed := range allowlist {
+		if subPath == allowed || strings.HasPrefix(subPath, allowed+"/") {
+			return true
+		}
 	}
+	return false
+}
 
-	proxy.ServeHTTP(resp, req)
-	return nil, nil
+// hopByHopHeaders are stripped from any request forwarded to a metrics-proxy
+// backend, even if a backend's ForwardHeaders is misconfigured to include
+// them.
+var hopByHopHeaders = map[string]bool{
+	"Connection":          true,
+	"Keep-Alive":          true,
+	"Proxy-Authenticate":  true,
+	"Proxy-Authorization": true,
+	"Te":                  true,
+	"Trailers":            true,
+	"Transfer-Encoding":   true,
+	"Upgrade":             true,
+}
+
+// uiMetricsProxySetHeaders rebuilds r's headers from scratch: only headers
+// named in backend.ForwardHeaders are copied over from the original incoming
+// request, and backend.AddHeaders are then applied on top. Because the
+// static headers are set last, a client can never override them by sending a
+// header with the same name.
+func uiMetricsProxySetHeaders(r, orig *http.Request, backend config.UIMetricsProxyBackend) {
+	forwarded := make(http.Header)
+	for _, name := range backend.ForwardHeaders {
+		canonical := http.CanonicalHeaderKey(name)
+		if hopByHopHeaders[canonical] {
+			continue
+		}
+		if vs := orig.Header.Values(canonical); len(vs) > 0 {
+			forwarded[canonical] = vs
+		}
+	}
+	r.Header = forwarded
+
+	for _, h := range backend.AddHeaders {
+		r.Header.Set(h.Name, h.Value)
+	}
+}
+
+// uiMetricsProxyACLAllowed checks authz against rule: "operator:write" checks
+// OperatorWrite, and any other rule, including an empty or unrecognized one,
+// checks OperatorRead. Callers reject rules outside the valid set with
+// UIMetricsProxyBackend.Validate before calling this.
+func uiMetricsProxyACLAllowed(authz acl.Authorizer, rule string) bool {
+	switch rule {
+	case "operator:write":
+		return authz.OperatorWrite(nil) == acl.Allow
+	default:
+		return authz.OperatorRead(nil) == acl.Allow
+	}
+}
+
+// UIMetricsProxyBackendSummary describes a configured metrics-proxy backend
+// for the UI's backend selector.
+type UIMetricsProxyBackendSummary struct {
+	Name string
+	Kind string
+}
+
+// UIMetricsProxyBackends handles GET /v1/internal/ui/metrics-proxy-backends,
+// returning the names and kinds of configured metrics-proxy backends so the
+// UI can populate a backend selector instead of hard-coding Prometheus.
+// Backends with RequireACLToken set are only listed for callers whose ACL
+// token satisfies them - this endpoint doesn't leak the existence of a
+// restricted backend to an unauthorized caller.
+func (s *HTTPHandlers) UIMetricsProxyBackends(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if !s.IsUIEnabled() {
+		return nil, NotFoundError{Reason: "UI is not enabled"}
+	}
+
+	cfg, ok := s.metricsProxyCfg.Load().(config.UIMetricsProxy)
+	if !ok {
+		return []UIMetricsProxyBackendSummary{}, nil
+	}
+
+	var token string
+	s.parseToken(req, &token)
+	authz, err := s.agent.resolveToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	log := s.agent.logger.Named(logging.UIMetricsProxy)
+
+	backends := make([]UIMetricsProxyBackendSummary, 0, len(cfg.Backends))
+	for _, b := range cfg.Backends {
+		if b.RequireACLToken {
+			if err := b.Validate(); err != nil {
+				log.Error("invalid metrics-proxy backend ACL configuration", "backend", b.Name, "error", err)
+				continue
+			}
+			if authz != nil && !uiMetricsProxyACLAllowed(authz, b.ACLRule) {
+				continue
+			}
+		}
+		backends = append(backends, UIMetricsProxyBackendSummary{Name: b.Name, Kind: b.Kind})
+	}
+	return backends, nil
 }
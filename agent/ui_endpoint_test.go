@@ -0,0 +1,300 @@
+package agent
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/config"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// testAuthorizer lets tests fix the decision for whichever acl.Authorizer
+// method they care about; every other method panics if called, via the
+// nil embedded interface.
+type testAuthorizer struct {
+	acl.Authorizer
+	operatorRead  acl.EnforcementDecision
+	operatorWrite acl.EnforcementDecision
+}
+
+func (a testAuthorizer) OperatorRead(*acl.AuthorizerContext) acl.EnforcementDecision {
+	return a.operatorRead
+}
+
+func (a testAuthorizer) OperatorWrite(*acl.AuthorizerContext) acl.EnforcementDecision {
+	return a.operatorWrite
+}
+
+func newTestCheckServiceNode(service, node string) structs.CheckServiceNode {
+	return structs.CheckServiceNode{
+		Node:    &structs.Node{Node: node},
+		Service: &structs.NodeService{Service: service},
+	}
+}
+
+// TestSummarizeServicesIter_GroupsOutOfOrderInstances guards against
+// regressing the fold-as-we-go grouping in summarizeServicesIter:
+// Internal.ServiceDump/Internal.GatewayServiceDump order their results by
+// node, not by service, so a real dump interleaves each service's
+// instances rather than keeping them contiguous.
+func TestSummarizeServicesIter_GroupsOutOfOrderInstances(t *testing.T) {
+	dump := structs.ServiceDump{
+		newTestCheckServiceNode("web", "node1"),
+		newTestCheckServiceNode("api", "node1"),
+		newTestCheckServiceNode("web", "node2"),
+		newTestCheckServiceNode("api", "node2"),
+	}
+
+	cfg := &config.RuntimeConfig{}
+
+	var got []*ServiceSummary
+	err := summarizeServicesIter(dump, cfg, "dc1", func(sum *ServiceSummary) error {
+		got = append(got, sum)
+		return nil
+	})
+	require.NoError(t, err)
+
+	want := summarizeServices(dump, cfg, "dc1")
+	require.Len(t, got, len(want))
+
+	byName := make(map[string]*ServiceSummary, len(got))
+	for _, sum := range got {
+		byName[sum.Name] = sum
+	}
+	for _, w := range want {
+		g, ok := byName[w.Name]
+		require.True(t, ok, "missing streamed summary for service %q", w.Name)
+		require.Equal(t, w.InstanceCount, g.InstanceCount)
+		require.Equal(t, w.Nodes, g.Nodes)
+	}
+}
+
+func TestPaginateServiceSummaries(t *testing.T) {
+	summaries := []*ServiceSummary{
+		{Name: "api"},
+		{Name: "db"},
+		{Name: "web"},
+	}
+
+	page, next := paginateServiceSummaries(summaries, uiPage{Limit: 2})
+	require.Equal(t, []string{"api", "db"}, serviceSummaryNames(page))
+	require.Equal(t, "db", next)
+
+	page, next = paginateServiceSummaries(summaries, uiPage{After: next})
+	require.Equal(t, []string{"web"}, serviceSummaryNames(page))
+	require.Empty(t, next)
+
+	page, next = paginateServiceSummaries(summaries, uiPage{After: "web"})
+	require.Empty(t, page)
+	require.Empty(t, next)
+}
+
+func serviceSummaryNames(summaries []*ServiceSummary) []string {
+	names := make([]string, len(summaries))
+	for i, sum := range summaries {
+		names[i] = sum.Name
+	}
+	return names
+}
+
+func TestPaginateNodeDump(t *testing.T) {
+	dump := structs.NodeDump{
+		{Node: "node1"},
+		{Node: "node2"},
+		{Node: "node3"},
+	}
+
+	page, next := paginateNodeDump(dump, uiPage{Limit: 2})
+	require.Equal(t, []string{"node1", "node2"}, nodeDumpNames(page))
+	require.Equal(t, "node2", next)
+
+	page, next = paginateNodeDump(dump, uiPage{After: next})
+	require.Equal(t, []string{"node3"}, nodeDumpNames(page))
+	require.Empty(t, next)
+}
+
+func nodeDumpNames(dump structs.NodeDump) []string {
+	names := make([]string, len(dump))
+	for i, info := range dump {
+		names[i] = info.Node
+	}
+	return names
+}
+
+func TestProjectUIFields(t *testing.T) {
+	summaries := []*ServiceSummary{
+		{Name: "web", InstanceCount: 3, ChecksPassing: 2},
+	}
+
+	got := projectUIFields(summaries, []string{"Name", "InstanceCount", "NotARealField"})
+	require.Len(t, got, 1)
+	require.Equal(t, "web", got[0]["Name"])
+	require.Equal(t, 3, got[0]["InstanceCount"])
+	require.NotContains(t, got[0], "NotARealField")
+	require.NotContains(t, got[0], "ChecksPassing")
+}
+
+func TestFindUIMetricsProxyBackend(t *testing.T) {
+	cfg := config.UIMetricsProxy{
+		Backends: []config.UIMetricsProxyBackend{
+			{Name: "prom", Kind: "prometheus"},
+			{Name: "loki", Kind: "loki"},
+		},
+	}
+
+	b, ok := findUIMetricsProxyBackend(cfg, "loki")
+	require.True(t, ok)
+	require.Equal(t, "loki", b.Kind)
+
+	_, ok = findUIMetricsProxyBackend(cfg, "missing")
+	require.False(t, ok)
+}
+
+func TestUIMetricsProxyPathAllowed(t *testing.T) {
+	allowlist := []string{"/api/v1/query", "/api/v1/query_range"}
+
+	require.True(t, uiMetricsProxyPathAllowed("/api/v1/query", allowlist))
+	require.True(t, uiMetricsProxyPathAllowed("/api/v1/query/sub", allowlist))
+	require.False(t, uiMetricsProxyPathAllowed("/api/v1/query_range_extra", allowlist))
+	require.False(t, uiMetricsProxyPathAllowed("/api/v1/admin", allowlist))
+
+	// An empty allowlist means "no restriction"; buildMetricsProxyTargetURL
+	// only calls this when the allowlist is non-empty, but the function
+	// itself should still be safe to call that way.
+	require.False(t, uiMetricsProxyPathAllowed("/anything", nil))
+}
+
+func TestBuildMetricsProxyTargetURL(t *testing.T) {
+	t.Run("appends path prefix and sub-path to the base URL", func(t *testing.T) {
+		backend := config.UIMetricsProxyBackend{
+			BaseURL:    "http://prom.internal:9090",
+			PathPrefix: "/prometheus",
+		}
+
+		u, err := buildMetricsProxyTargetURL(backend, "/api/v1/query")
+		require.NoError(t, err)
+		require.Equal(t, "http://prom.internal:9090/prometheus/api/v1/query", u.String())
+	})
+
+	t.Run("rejects a path that escapes the base URL via traversal", func(t *testing.T) {
+		backend := config.UIMetricsProxyBackend{BaseURL: "http://prom.internal:9090/prometheus"}
+
+		_, err := buildMetricsProxyTargetURL(backend, "/../../secret")
+		require.Error(t, err)
+	})
+
+	t.Run("rejects a path outside the backend's allowlist", func(t *testing.T) {
+		backend := config.UIMetricsProxyBackend{
+			BaseURL:       "http://prom.internal:9090",
+			PathAllowlist: []string{"/api/v1/query"},
+		}
+
+		_, err := buildMetricsProxyTargetURL(backend, "/api/v1/admin")
+		require.Error(t, err)
+	})
+
+	t.Run("allows a path inside the backend's allowlist", func(t *testing.T) {
+		backend := config.UIMetricsProxyBackend{
+			BaseURL:       "http://prom.internal:9090",
+			PathAllowlist: []string{"/api/v1/query"},
+		}
+
+		u, err := buildMetricsProxyTargetURL(backend, "/api/v1/query")
+		require.NoError(t, err)
+		require.Equal(t, "http://prom.internal:9090/api/v1/query", u.String())
+	})
+}
+
+func TestUIMetricsProxySetHeaders(t *testing.T) {
+	t.Run("hop-by-hop headers are dropped even if misconfigured into ForwardHeaders", func(t *testing.T) {
+		orig := httptest.NewRequest("GET", "/", nil)
+		orig.Header.Set("Connection", "keep-alive")
+		orig.Header.Set("X-Custom", "from-client")
+
+		backend := config.UIMetricsProxyBackend{
+			ForwardHeaders: []string{"Connection", "X-Custom"},
+		}
+
+		r := httptest.NewRequest("GET", "/", nil)
+		uiMetricsProxySetHeaders(r, orig, backend)
+
+		require.Empty(t, r.Header.Get("Connection"))
+		require.Equal(t, "from-client", r.Header.Get("X-Custom"))
+	})
+
+	t.Run("a header not in ForwardHeaders is dropped", func(t *testing.T) {
+		orig := httptest.NewRequest("GET", "/", nil)
+		orig.Header.Set("X-Not-Forwarded", "secret")
+
+		backend := config.UIMetricsProxyBackend{ForwardHeaders: []string{"X-Custom"}}
+
+		r := httptest.NewRequest("GET", "/", nil)
+		uiMetricsProxySetHeaders(r, orig, backend)
+
+		require.Empty(t, r.Header.Get("X-Not-Forwarded"))
+	})
+
+	t.Run("a static AddHeaders entry overwrites a client-supplied header of the same name instead of merging with it", func(t *testing.T) {
+		orig := httptest.NewRequest("GET", "/", nil)
+		orig.Header.Set("Authorization", "Bearer client-supplied")
+
+		backend := config.UIMetricsProxyBackend{
+			ForwardHeaders: []string{"Authorization"},
+			AddHeaders: []config.UIMetricsProxyAddHeader{
+				{Name: "Authorization", Value: "Bearer configured"},
+			},
+		}
+
+		r := httptest.NewRequest("GET", "/", nil)
+		uiMetricsProxySetHeaders(r, orig, backend)
+
+		require.Equal(t, []string{"Bearer configured"}, r.Header.Values("Authorization"))
+	})
+}
+
+func TestUIMetricsProxyACLAllowed(t *testing.T) {
+	cases := map[string]struct {
+		rule  string
+		authz testAuthorizer
+		want  bool
+	}{
+		"empty rule defaults to operator:read, allowed": {
+			rule:  "",
+			authz: testAuthorizer{operatorRead: acl.Allow},
+			want:  true,
+		},
+		"empty rule defaults to operator:read, denied": {
+			rule:  "",
+			authz: testAuthorizer{operatorRead: acl.Deny},
+			want:  false,
+		},
+		"operator:read": {
+			rule:  "operator:read",
+			authz: testAuthorizer{operatorRead: acl.Allow},
+			want:  true,
+		},
+		"operator:write allowed": {
+			rule:  "operator:write",
+			authz: testAuthorizer{operatorWrite: acl.Allow, operatorRead: acl.Deny},
+			want:  true,
+		},
+		"operator:write denied": {
+			rule:  "operator:write",
+			authz: testAuthorizer{operatorWrite: acl.Deny, operatorRead: acl.Allow},
+			want:  false,
+		},
+		"unrecognized rule falls back to operator:read": {
+			rule:  "mesh:read",
+			authz: testAuthorizer{operatorRead: acl.Allow},
+			want:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, uiMetricsProxyACLAllowed(tc.authz, tc.rule))
+		})
+	}
+}